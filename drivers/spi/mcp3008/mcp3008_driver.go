@@ -0,0 +1,91 @@
+// Package mcp3008 provides a gobot driver for the MCP3004/MCP3008 family of
+// 10-bit successive-approximation SPI analog-to-digital converters. The
+// MCP3208 is NOT supported: it is a 12-bit part with a different control-byte
+// alignment and readback width, and sending it this driver's transaction
+// would send the wrong control bits and truncate two data bits.
+package mcp3008
+
+import (
+	"fmt"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/spi"
+)
+
+// Mode selects how a channel is sampled.
+type Mode byte
+
+const (
+	// SingleEnded samples a single channel against ground.
+	SingleEnded Mode = 1
+	// Differential samples a channel against its neighbour.
+	Differential Mode = 0
+)
+
+// maxChannel is the highest channel index on an MCP3008; callers targeting
+// an MCP3004 simply never address channels above 3.
+const maxChannel = 7
+
+// Driver is the gobot driver for the MCP3004/MCP3008.
+type Driver struct {
+	name       string
+	connector  spi.Connector
+	connection spi.Connection
+	gobot.Commander
+}
+
+// NewDriver creates a new Driver connected through the given spi.Connector.
+func NewDriver(a spi.Connector) *Driver {
+	return &Driver{
+		name:      gobot.DefaultName("MCP3008"),
+		connector: a,
+		Commander: gobot.NewCommander(),
+	}
+}
+
+// Name returns the name of the device.
+func (d *Driver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *Driver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the device.
+func (d *Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start opens the spi connection to the device.
+func (d *Driver) Start() (err error) {
+	bus := d.connector.GetSpiDefaultBus()
+	chip := d.connector.GetSpiDefaultChip()
+	mode := d.connector.GetSpiDefaultMode()
+	bits := d.connector.GetSpiDefaultBits()
+	maxSpeed := d.connector.GetSpiDefaultMaxSpeed()
+
+	d.connection, err = d.connector.GetSpiConnection(bus, chip, mode, bits, maxSpeed)
+	return
+}
+
+// Halt stops the device.
+func (d *Driver) Halt() error { return nil }
+
+// Read performs a conversion on channel (0-7) in the given Mode and returns
+// the 10-bit result.
+func (d *Driver) Read(channel int, mode Mode) (int, error) {
+	if channel < 0 || channel > maxChannel {
+		return 0, fmt.Errorf("channel %d out of range [0..%d]", channel, maxChannel)
+	}
+
+	tx := []byte{0x01, byte(mode)<<7 | byte(channel)<<4, 0x00}
+	rx := make([]byte, len(tx))
+	if err := d.connection.Tx(tx, rx); err != nil {
+		return 0, err
+	}
+
+	return int(rx[1]&0x03)<<8 | int(rx[2]), nil
+}
+
+// AnalogRead performs a single-ended conversion on channel and returns the
+// 10-bit result. It satisfies the odroid.AnalogPinReader interface expected
+// by Adaptor.RegisterAnalogReader.
+func (d *Driver) AnalogRead(channel int) (int, error) {
+	return d.Read(channel, SingleEnded)
+}
@@ -0,0 +1,97 @@
+package mcp3008
+
+import (
+	"bytes"
+	"testing"
+
+	"gobot.io/x/gobot/drivers/spi"
+)
+
+type fakeSPIConnection struct {
+	tx func(w, r []byte) error
+}
+
+func (f *fakeSPIConnection) Tx(w, r []byte) error { return f.tx(w, r) }
+func (f *fakeSPIConnection) Close() error         { return nil }
+
+type fakeSPIConnector struct {
+	conn spi.Connection
+}
+
+func (f *fakeSPIConnector) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (spi.Connection, error) {
+	return f.conn, nil
+}
+func (f *fakeSPIConnector) GetSpiDefaultBus() int        { return 0 }
+func (f *fakeSPIConnector) GetSpiDefaultChip() int       { return 0 }
+func (f *fakeSPIConnector) GetSpiDefaultMode() int       { return 0 }
+func (f *fakeSPIConnector) GetSpiDefaultBits() int       { return 8 }
+func (f *fakeSPIConnector) GetSpiDefaultMaxSpeed() int64 { return 500000 }
+
+func TestDriverRead(t *testing.T) {
+	cases := []struct {
+		name    string
+		channel int
+		mode    Mode
+		rx      []byte
+		want    int
+	}{
+		{"single-ended channel 0, zero", 0, SingleEnded, []byte{0x00, 0x00, 0x00}, 0},
+		{"single-ended channel 3, max", 3, SingleEnded, []byte{0x00, 0x03, 0xff}, 1023},
+		{"differential channel 5", 5, Differential, []byte{0x00, 0x01, 0x23}, 0x123},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var gotTx []byte
+			conn := &fakeSPIConnection{tx: func(w, r []byte) error {
+				gotTx = w
+				copy(r, c.rx)
+				return nil
+			}}
+			d := NewDriver(&fakeSPIConnector{conn: conn})
+			if err := d.Start(); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+
+			got, err := d.Read(c.channel, c.mode)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("Read(%d, %v) = %d, want %d", c.channel, c.mode, got, c.want)
+			}
+
+			wantTx := []byte{0x01, byte(c.mode)<<7 | byte(c.channel)<<4, 0x00}
+			if !bytes.Equal(gotTx, wantTx) {
+				t.Errorf("tx = % x, want % x", gotTx, wantTx)
+			}
+		})
+	}
+}
+
+func TestDriverReadChannelOutOfRange(t *testing.T) {
+	d := NewDriver(&fakeSPIConnector{})
+	if _, err := d.Read(maxChannel+1, SingleEnded); err == nil {
+		t.Fatal("expected an error for an out-of-range channel")
+	}
+}
+
+func TestDriverAnalogReadIsSingleEnded(t *testing.T) {
+	var gotMode Mode
+	conn := &fakeSPIConnection{tx: func(w, r []byte) error {
+		gotMode = Mode(w[1] >> 7)
+		copy(r, []byte{0x00, 0x00, 0x00})
+		return nil
+	}}
+	d := NewDriver(&fakeSPIConnector{conn: conn})
+	if err := d.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if _, err := d.AnalogRead(0); err != nil {
+		t.Fatalf("AnalogRead: %v", err)
+	}
+	if gotMode != SingleEnded {
+		t.Errorf("AnalogRead used mode %v, want SingleEnded", gotMode)
+	}
+}
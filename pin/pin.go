@@ -0,0 +1,96 @@
+// Package pin holds the pin capability/descriptor vocabulary shared by
+// platform adaptors (odroid, beaglebone, raspi, ...) so a board only has to
+// declare its pin table once, with full capability metadata, instead of
+// every adaptor inventing its own flat name->channel map.
+package pin
+
+import "fmt"
+
+// Capability is a bitmask describing what a pin on a board can be used for.
+type Capability uint8
+
+// Capability flags. A pin may advertise more than one.
+//
+// There is deliberately no CapI2C/CapSPI/CapUART here: on every board this
+// package currently describes, I2C/SPI are bus-level resources addressed by
+// bus number (see Descriptor.I2CBuses/SPIBuses in platforms/odroid/host),
+// not by an individual named header pin the way digital/PWM/analog are, so
+// a per-pin capability flag for them would have nothing real to attach to.
+const (
+	CapDigital Capability = 1 << iota
+	CapPWM
+	CapAnalog
+)
+
+// String returns a human readable list of the set capabilities, e.g. "digital|pwm".
+func (c Capability) String() string {
+	names := []struct {
+		flag Capability
+		name string
+	}{
+		{CapDigital, "digital"},
+		{CapPWM, "pwm"},
+		{CapAnalog, "analog"},
+	}
+
+	s := ""
+	for _, n := range names {
+		if c&n.flag != 0 {
+			if s != "" {
+				s += "|"
+			}
+			s += n.name
+		}
+	}
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// Desc describes a single physical pin on a board: what it is capable of
+// and the channel(s) the underlying kernel interfaces expect.
+type Desc struct {
+	// Caps is the set of capabilities this pin supports.
+	Caps Capability
+
+	// DigitalChannel is the global GPIO number used for CapDigital access
+	// through the legacy /sys/class/gpio sysfs ABI.
+	DigitalChannel int
+
+	// Chip and Line identify this pin's line on a gpiochip character
+	// device, for boards/backends that support the gpio-cdev ABI. They are
+	// independent of DigitalChannel because a SoC's GPIO banks are rarely a
+	// uniform stride apart.
+	Chip string
+	Line uint32
+
+	// AnalogChannel is the sysfs iio channel name used for CapAnalog access,
+	// e.g. "in_voltage0_raw".
+	AnalogChannel string
+}
+
+// Map maps every alias a pin is known by (e.g. "4", "GPIO_173", "P1_3") to
+// its descriptor. A pin with several aliases appears under each one.
+type Map map[string]Desc
+
+// NotFoundError is returned when no pin matches a given name.
+type NotFoundError struct {
+	Pin string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%q is not a valid pin", e.Pin)
+}
+
+// CapabilityError is returned when a pin exists but does not support the
+// requested capability.
+type CapabilityError struct {
+	Pin      string
+	Required Capability
+	Have     Capability
+}
+
+func (e *CapabilityError) Error() string {
+	return fmt.Sprintf("pin %q does not support %s (has %s)", e.Pin, e.Required, e.Have)
+}
@@ -0,0 +1,128 @@
+// Package host holds the per-board metadata that lets platforms/odroid
+// support more than one Hardkernel Odroid board without hardcoding a
+// single pin table into Adaptor.
+//
+// Each board registers a Descriptor describing its pin table and bus
+// layout; Adaptor resolves one at construction time, either by name or by
+// auto-detecting the running board, and delegates all board-specific
+// constants to it. The pin capability vocabulary itself (Capability, pin
+// descriptors, pin errors) lives in gobot.io/x/gobot/pin so that other
+// platform adaptors can declare their own board registries against the same
+// types without depending on this Odroid-specific package.
+//
+// Only the Odroid XU4 has a registered Descriptor so far; detecting any
+// other board (C1+, C2, C4, N2, N2+, HC1, ...) returns an error from
+// Detect rather than guessing, since a wrong guess would silently resolve
+// a digital pin against the wrong board's table. Adding a board here
+// requires that board's verified pin table, the same way xu4.go's was
+// built from XU4 gpioinfo output.
+package host
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"gobot.io/x/gobot/pin"
+)
+
+// Capability, PinDesc, PinMap and the pin errors are aliases onto
+// gobot.io/x/gobot/pin so existing callers of this package keep working.
+type (
+	Capability         = pin.Capability
+	PinDesc            = pin.Desc
+	PinMap             = pin.Map
+	PinNotFoundError   = pin.NotFoundError
+	PinCapabilityError = pin.CapabilityError
+)
+
+// Capability flags. A pin may advertise more than one.
+const (
+	CapDigital = pin.CapDigital
+	CapPWM     = pin.CapPWM
+	CapAnalog  = pin.CapAnalog
+)
+
+// PWMPinData describes a board's PWM-capable pin in terms of the sysfs
+// pwmchipN/pwmM that drives it: Chip is N, Channel is M. Boards with PWM
+// pins spread across more than one pwmchip (unlike the XU4, which has just
+// pwmchip0) rely on Chip to tell them apart.
+type PWMPinData struct {
+	Chip    int
+	Channel int
+}
+
+// Descriptor is everything board-specific that platforms/odroid.Adaptor
+// needs: its pin table and the buses it exposes.
+type Descriptor struct {
+	// Name is the canonical board name, e.g. "Odroid-XU4".
+	Name string
+
+	// Aliases are the /proc/device-tree/model or /etc/board strings that
+	// identify this board, used by Detect.
+	Aliases []string
+
+	DigitalPins     PinMap
+	AnalogPins      PinMap
+	AnalogSysfsGlob string
+	I2CBuses        []int
+	SPIBuses        []int
+	DefaultI2CBus   int
+	PWMChips        map[string]PWMPinData
+}
+
+var (
+	registryMutex sync.Mutex
+	registry      = make(map[string]*Descriptor)
+)
+
+// Register makes a board's Descriptor available to Get and Detect. Board
+// files call this from an init() function.
+func Register(name string, d *Descriptor) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	registry[name] = d
+}
+
+// Get returns the Descriptor registered under name, if any.
+func Get(name string) (*Descriptor, bool) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	d, ok := registry[name]
+	return d, ok
+}
+
+// Detect identifies the board this process is running on by matching
+// /proc/device-tree/model (falling back to /etc/board) against every
+// registered Descriptor's Aliases.
+func Detect() (*Descriptor, error) {
+	model := readBoardModel()
+	if model == "" {
+		return nil, fmt.Errorf("host: could not read board model from /proc/device-tree/model or /etc/board")
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	for _, d := range registry {
+		for _, alias := range d.Aliases {
+			if strings.Contains(model, alias) {
+				return d, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("host: no registered board matches model %q", model)
+}
+
+func readBoardModel() string {
+	if b, err := ioutil.ReadFile("/proc/device-tree/model"); err == nil {
+		return strings.Trim(string(b), "\x00\n ")
+	}
+	if b, err := ioutil.ReadFile("/etc/board"); err == nil {
+		return strings.TrimSpace(string(b))
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package host
+
+// XU4Name is the canonical name of the Odroid XU4 Descriptor.
+const XU4Name = "Odroid-XU4"
+
+func init() {
+	digital := PinMap{}
+	// chip/line come from `gpioinfo` on a physical XU4, not from any
+	// formula: the Exynos5422 pinctrl banks this board's lines sit in
+	// (gpa2, gpx0, gpx1, gpx2, ...) are irregular widths, so DigitalChannel
+	// (the legacy sysfs global GPIO number) cannot be divided by a constant
+	// stride to recover a gpiochip/line pair.
+	addDigital := func(channel int, chip string, line uint32, aliases ...string) {
+		d := PinDesc{Caps: CapDigital, DigitalChannel: channel, Chip: chip, Line: line}
+		for _, a := range aliases {
+			digital[a] = d
+		}
+	}
+	addDigital(173, "gpx1", 0, "4")
+	addDigital(174, "gpx1", 1, "5")
+	addDigital(171, "gpx1", 2, "6")
+	addDigital(192, "gpx1", 3, "7")
+	addDigital(172, "gpx1", 4, "8")
+	addDigital(191, "gpx1", 5, "9")
+	addDigital(189, "gpx1", 6, "10")
+	addDigital(190, "gpx1", 7, "11")
+	addDigital(21, "gpa2", 0, "13")
+	addDigital(210, "gpa2", 1, "14")
+	addDigital(18, "gpa2", 2, "15")
+	addDigital(209, "gpa2", 3, "16")
+	addDigital(22, "gpa2", 4, "17")
+	addDigital(19, "gpa2", 5, "18")
+	addDigital(30, "gpa2", 6, "19")
+	addDigital(28, "gpa2", 7, "20")
+	addDigital(29, "gpx2", 0, "21")
+	addDigital(31, "gpx2", 1, "22")
+	addDigital(25, "gpx2", 2, "24")
+	addDigital(23, "gpx2", 3, "25")
+	addDigital(24, "gpx2", 4, "26")
+	addDigital(33, "gpx2", 5, "27")
+	addDigital(188, "gpx2", 6, "[4]")
+	addDigital(34, "gpx2", 7, "[5]")
+	addDigital(187, "gpx0", 0, "[6]")
+
+	analog := PinMap{
+		"3":    {Caps: CapAnalog, AnalogChannel: "in_voltage0_raw"},
+		"AIN0": {Caps: CapAnalog, AnalogChannel: "in_voltage0_raw"},
+		"23":   {Caps: CapAnalog, AnalogChannel: "in_voltage3_raw"},
+		"AIN3": {Caps: CapAnalog, AnalogChannel: "in_voltage3_raw"},
+	}
+
+	// The XU4 exposes two PWM-capable header pins, each muxed off an
+	// otherwise plain digital pin.
+	pwmChips := map[string]PWMPinData{
+		"19": {Chip: 0, Channel: 0},
+		"21": {Chip: 0, Channel: 1},
+	}
+	for name := range pwmChips {
+		d := digital[name]
+		d.Caps |= CapPWM
+		digital[name] = d
+	}
+
+	Register(XU4Name, &Descriptor{
+		Name:            XU4Name,
+		Aliases:         []string{"Hardkernel Odroid-XU4", "Odroid-XU4", "ODROID-XU4"},
+		DigitalPins:     digital,
+		AnalogPins:      analog,
+		AnalogSysfsGlob: "/sys/devices/12d10000.adc/iio:device0/",
+		I2CBuses:        []int{0, 2},
+		SPIBuses:        []int{0, 1},
+		DefaultI2CBus:   1,
+		PWMChips:        pwmChips,
+	})
+}
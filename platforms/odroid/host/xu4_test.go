@@ -0,0 +1,25 @@
+package host
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestXU4DigitalPinsHaveUniqueChipLine(t *testing.T) {
+	d, ok := Get(XU4Name)
+	if !ok {
+		t.Fatal("XU4 descriptor not registered")
+	}
+
+	chipLineToChannel := map[string]int{}
+	for alias, desc := range d.DigitalPins {
+		if desc.Chip == "" {
+			t.Errorf("pin %q: Chip is empty", alias)
+		}
+		key := fmt.Sprintf("%s:%d", desc.Chip, desc.Line)
+		if channel, seen := chipLineToChannel[key]; seen && channel != desc.DigitalChannel {
+			t.Errorf("pin %q: chip/line %s already claimed by GPIO %d, also claimed by GPIO %d", alias, key, channel, desc.DigitalChannel)
+		}
+		chipLineToChannel[key] = desc.DigitalChannel
+	}
+}
@@ -1,7 +1,6 @@
 package odroid
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,45 +12,72 @@ import (
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/drivers/i2c"
 	"gobot.io/x/gobot/drivers/spi"
+	"gobot.io/x/gobot/platforms/odroid/host"
 	"gobot.io/x/gobot/sysfs"
 )
 
-type pwmPinData struct {
-	channel int
-	path    string
-}
-
 const pwmDefaultPeriod = 500000
 
-// Adaptor is the gobot.Adaptor representation for the Odroid XU4
+// Adaptor is the gobot.Adaptor representation for Hardkernel's Odroid
+// single-board computers. Everything that differs between boards is
+// delegated to a host.Descriptor resolved at construction time; only the
+// Odroid XU4 has a registered Descriptor so far (see the host package doc).
 type Adaptor struct {
 	name               string
+	descriptor         *host.Descriptor
 	digitalPins        []*sysfs.DigitalPin
 	pwmPins            map[string]*sysfs.PWMPin
 	i2cBuses           map[int]i2c.I2cDevice
 	usrLed             string
 	analogPath         string
-	pinMap             map[string]int
-	analogPinMap       map[string]string
+	analogReaders      map[string]AnalogPinReader
+	gpioCDevChipName   string
+	cdevPins           map[int]*sysfs.GPIOCDevPin
 	mutex              *sync.Mutex
 	findPin            func(pinPath string) (string, error)
 	spiDefaultBus      int
 	spiDefaultChip     int
-	spiBuses           [2]spi.Connection
+	spiBuses           map[int]spi.Connection
 	spiDefaultMode     int
 	spiDefaultMaxSpeed int64
 }
 
-// NewAdaptor returns a new Odroid Adaptor
-func NewAdaptor() *Adaptor {
+// NewAdaptor returns a new Odroid Adaptor for the board it is running on,
+// detected from /proc/device-tree/model or /etc/board. It returns an error
+// if the running board has no registered Descriptor (currently just the
+// XU4) rather than guessing, since defaulting to the wrong board's pin
+// table would silently drive the wrong physical pin. Use
+// NewAdaptorForBoard to target a specific board without relying on
+// detection.
+func NewAdaptor() (*Adaptor, error) {
+	descriptor, err := host.Detect()
+	if err != nil {
+		return nil, err
+	}
+	return newAdaptor(descriptor), nil
+}
+
+// NewAdaptorForBoard returns a new Odroid Adaptor for the named board
+// instead of relying on auto-detection, e.g. NewAdaptorForBoard(host.XU4Name).
+func NewAdaptorForBoard(name string) (*Adaptor, error) {
+	descriptor, ok := host.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("odroid: no such board %q", name)
+	}
+	return newAdaptor(descriptor), nil
+}
+
+func newAdaptor(descriptor *host.Descriptor) *Adaptor {
 	b := &Adaptor{
-		name:         gobot.DefaultName("Odroid-XU4"),
-		digitalPins:  make([]*sysfs.DigitalPin, 120),
-		pwmPins:      make(map[string]*sysfs.PWMPin),
-		i2cBuses:     make(map[int]i2c.I2cDevice),
-		mutex:        &sync.Mutex{},
-		pinMap:       DigitalPinMap,
-		analogPinMap: AnalogPinMap,
+		name:          gobot.DefaultName(descriptor.Name),
+		descriptor:    descriptor,
+		digitalPins:   make([]*sysfs.DigitalPin, 120),
+		pwmPins:       make(map[string]*sysfs.PWMPin),
+		i2cBuses:      make(map[int]i2c.I2cDevice),
+		analogReaders: make(map[string]AnalogPinReader),
+		cdevPins:      make(map[int]*sysfs.GPIOCDevPin),
+		spiBuses:      make(map[int]spi.Connection),
+		mutex:         &sync.Mutex{},
 		findPin: func(pinPath string) (string, error) {
 			files, err := filepath.Glob(pinPath)
 			return files[0], err
@@ -62,9 +88,19 @@ func NewAdaptor() *Adaptor {
 	return b
 }
 
+// UseGPIOCDev switches digital pin access from the deprecated
+// /sys/class/gpio sysfs tree to the /dev/gpiochip* character-device ABI.
+// chipDevPrefix is the device path prefix used to reach a pin's gpiochip,
+// e.g. "/dev/gpiochip-"; each pin's PinDesc.Chip bank name (e.g. "gpx1") is
+// appended to it, since the XU4 registers one gpiochip per Exynos GPIO bank.
+// It must be called before the first DigitalPin/DigitalRead/DigitalWrite.
+func (o *Adaptor) UseGPIOCDev(chipDevPrefix string) {
+	o.gpioCDevChipName = chipDevPrefix
+}
+
 func (o *Adaptor) setPaths() {
-	o.analogPath = "/sys/devices/12d10000.adc/iio:device0/"
-	o.spiDefaultBus = 0
+	o.analogPath = o.descriptor.AnalogSysfsGlob
+	o.spiDefaultBus = o.descriptor.SPIBuses[0]
 	o.spiDefaultMode = 0
 	o.spiDefaultMaxSpeed = 500000
 }
@@ -92,6 +128,13 @@ func (b *Adaptor) Finalize() (err error) {
 			}
 		}
 	}
+	for _, pin := range b.cdevPins {
+		if pin != nil {
+			if e := pin.Unexport(); e != nil {
+				err = multierror.Append(err, e)
+			}
+		}
+	}
 	for _, pin := range b.pwmPins {
 		if pin != nil {
 			if e := pin.Unexport(); e != nil {
@@ -139,10 +182,24 @@ func (o *Adaptor) DigitalPin(pin string, dir string) (sysfsPin sysfs.DigitalPinn
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	i, err := o.translatePin(pin)
+	desc, err := o.lookupPin(pin, CapDigital)
 	if err != nil {
 		return
 	}
+	i := desc.DigitalChannel
+
+	if o.gpioCDevChipName != "" {
+		cdevPin := o.cdevPins[i]
+		if cdevPin == nil {
+			cdevPin = sysfs.NewGPIOCDevPin(o.gpioCDevChipName+desc.Chip, desc.Line)
+			o.cdevPins[i] = cdevPin
+		}
+		if err = cdevPin.Direction(dir); err != nil {
+			return
+		}
+		return cdevPin, nil
+	}
+
 	if o.digitalPins[i] == nil {
 		o.digitalPins[i] = sysfs.NewDigitalPin(i)
 		if err = muxPin(pin, "gpio"); err != nil {
@@ -160,14 +217,43 @@ func (o *Adaptor) DigitalPin(pin string, dir string) (sysfsPin sysfs.DigitalPinn
 	return o.digitalPins[i], nil
 }
 
+// AnalogPinReader is implemented by off-board ADCs (e.g. an MCP3008 hung off
+// an SPI bus) so they can be addressed through Adaptor.AnalogRead alongside
+// the board's native analog pins.
+type AnalogPinReader interface {
+	AnalogRead(channel int) (int, error)
+}
+
+// RegisterAnalogReader makes an off-board ADC's channels available through
+// AnalogRead as "name:channel", e.g. after
+// RegisterAnalogReader("MCP0", mcp3008.NewDriver(o)), "MCP0:3" reads channel 3.
+func (o *Adaptor) RegisterAnalogReader(name string, r AnalogPinReader) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 
-// AnalogRead returns an analog value from specified pin
+	o.analogReaders[name] = r
+}
+
+// AnalogRead returns an analog value from specified pin. Pins of the form
+// "name:channel" are dispatched to an ADC registered with
+// RegisterAnalogReader; all other names are resolved against the board's
+// native analog pins.
 func (o *Adaptor) AnalogRead(pin string) (val int, err error) {
-	analogPin, err := o.translateAnalogPin(pin)
+	if name, channel, ok := splitMultiplexedPin(pin); ok {
+		o.mutex.Lock()
+		r, ok := o.analogReaders[name]
+		o.mutex.Unlock()
+		if !ok {
+			return 0, &PinNotFoundError{Pin: pin}
+		}
+		return r.AnalogRead(channel)
+	}
+
+	desc, err := o.lookupPin(pin, CapAnalog)
 	if err != nil {
 		return
 	}
-	fi, err := sysfs.OpenFile(fmt.Sprintf("%v/%v", o.analogPath, analogPin), os.O_RDONLY, 0644)
+	fi, err := sysfs.OpenFile(fmt.Sprintf("%v/%v", o.analogPath, desc.AnalogChannel), os.O_RDONLY, 0644)
 	defer fi.Close()
 
 	if err != nil {
@@ -184,13 +270,98 @@ func (o *Adaptor) AnalogRead(pin string) (val int, err error) {
 	return
 }
 
+// servoPeriod is the pulse period expected by an RC servo, 20ms.
+const servoPeriod = 20000000
+
+// Minimum and maximum pulse widths, in nanoseconds, for a 0-180 degree servo.
+const (
+	minServoPulse = 500000
+	maxServoPulse = 2500000
+)
+
+// pwmPin returns the exported, enabled *sysfs.PWMPin for pin, muxing it to
+// "pwm" and exporting it on first use.
+func (o *Adaptor) pwmPin(pin string) (sysfsPin *sysfs.PWMPin, err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if _, err = o.lookupPin(pin, CapPWM); err != nil {
+		return
+	}
+	data, ok := o.descriptor.PWMChips[pin]
+	if !ok {
+		return nil, &PinCapabilityError{Pin: pin, Required: CapPWM}
+	}
+
+	if o.pwmPins[pin] == nil {
+		if err = muxPin(pin, "pwm"); err != nil {
+			return
+		}
+
+		newPin := sysfs.NewPWMPin(data.Chip, data.Channel)
+		if err = newPin.Export(); err != nil {
+			return
+		}
+		if period, e := newPin.Period(); e != nil || period == 0 {
+			if err = newPin.SetPeriod(pwmDefaultPeriod); err != nil {
+				return
+			}
+		}
+		if err = newPin.Enable(true); err != nil {
+			return
+		}
+		o.pwmPins[pin] = newPin
+	}
+	return o.pwmPins[pin], nil
+}
+
+// PwmWrite writes a PWM signal to the specified pin, mapping 0-255 to a
+// duty-cycle fraction of the pin's current period.
+func (o *Adaptor) PwmWrite(pin string, duty byte) (err error) {
+	sysfsPin, err := o.pwmPin(pin)
+	if err != nil {
+		return err
+	}
+	period, err := sysfsPin.Period()
+	if err != nil {
+		return err
+	}
+	return sysfsPin.SetDutyCycle(dutyCycleForDuty(duty, period))
+}
+
+// ServoWrite writes a servo signal to the specified pin, mapping 0-180
+// degrees to a 500000-2500000ns pulse at a 20ms period.
+func (o *Adaptor) ServoWrite(pin string, angle byte) (err error) {
+	sysfsPin, err := o.pwmPin(pin)
+	if err != nil {
+		return err
+	}
+	if err = sysfsPin.SetPeriod(servoPeriod); err != nil {
+		return err
+	}
+	return sysfsPin.SetDutyCycle(pulseForAngle(angle))
+}
+
+// dutyCycleForDuty scales duty (0-255) to a duty-cycle in nanoseconds of the
+// pin's period.
+func dutyCycleForDuty(duty byte, period uint32) uint32 {
+	return uint32(gobot.FromScale(float64(duty), 0, 255) * float64(period))
+}
+
+// pulseForAngle scales angle (0-180 degrees) to a pulse width in nanoseconds
+// between minServoPulse and maxServoPulse.
+func pulseForAngle(angle byte) uint32 {
+	return uint32(gobot.ToScale(gobot.FromScale(float64(angle), 0, 180), minServoPulse, maxServoPulse))
+}
+
 // GetConnection returns a connection to a device on a specified bus.
-// Valid bus number is either 0 or 2 which corresponds to /dev/i2c-0 or /dev/i2c-2.
+// Valid bus numbers are those in the resolved board's Descriptor.I2CBuses,
+// which correspond to /dev/i2c-N.
 func (o *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection, err error) {
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	if (bus != 0) && (bus != 2) {
+	if !containsInt(o.descriptor.I2CBuses, bus) {
 		return nil, fmt.Errorf("bus number %d out of range", bus)
 	}
 	if o.i2cBuses[bus] == nil {
@@ -201,16 +372,17 @@ func (o *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection
 
 // GetDefaultBus returns the default i2c bus for this platform
 func (o *Adaptor) GetDefaultBus() int {
-	return 1
+	return o.descriptor.DefaultI2CBus
 }
 
 // GetSpiConnection returns an spi connection to a device on a specified bus.
-// Valid bus number is [0..1] which corresponds to /dev/spidev0.0 through /dev/spidev0.1.
+// Valid bus numbers are those in the resolved board's Descriptor.SPIBuses,
+// which correspond to /dev/spidev0.N.
 func (o *Adaptor) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (connection spi.Connection, err error) {
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
-	if (busNum < 0) || (busNum > 1) {
+	if !containsInt(o.descriptor.SPIBuses, busNum) {
 		return nil, fmt.Errorf("bus number %d out of range", busNum)
 	}
 
@@ -246,24 +418,28 @@ func (o *Adaptor) GetSpiDefaultMaxSpeed() int64 {
 	return o.spiDefaultMaxSpeed
 }
 
-// translatePin converts digital pin name to pin position
-func (o *Adaptor) translatePin(pin string) (value int, err error) {
-	if val, ok := o.pinMap[pin]; ok {
-		value = val
-	} else {
-		err = errors.New("not a valid pin")
+// splitMultiplexedPin splits a "name:channel" pin address such as "MCP0:3"
+// into the registered reader name and channel number.
+func splitMultiplexedPin(pin string) (name string, channel int, ok bool) {
+	parts := strings.SplitN(pin, ":", 2)
+	if len(parts) != 2 {
+		return "", 0, false
 	}
-	return
+	channel, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, false
+	}
+	return parts[0], channel, true
 }
 
-// translateAnalogPin converts analog pin name to pin position
-func (o *Adaptor) translateAnalogPin(pin string) (value string, err error) {
-	if val, ok := o.analogPinMap[pin]; ok {
-		value = val
-	} else {
-		err = errors.New("not a valid analog pin")
+// containsInt reports whether v is present in buses.
+func containsInt(buses []int, v int) bool {
+	for _, b := range buses {
+		if b == v {
+			return true
+		}
 	}
-	return
+	return false
 }
 
 func muxPin(pin, cmd string) error {
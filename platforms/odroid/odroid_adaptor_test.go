@@ -0,0 +1,33 @@
+package odroid
+
+import "testing"
+
+func TestSplitMultiplexedPin(t *testing.T) {
+	cases := []struct {
+		pin         string
+		wantName    string
+		wantChannel int
+		wantOK      bool
+	}{
+		{"MCP0:3", "MCP0", 3, true},
+		{"MCP0:0", "MCP0", 0, true},
+		{"19", "", 0, false},
+		{"MCP0:", "", 0, false},
+		{"MCP0:foo", "", 0, false},
+		{":3", "", 3, true},
+	}
+
+	for _, c := range cases {
+		name, channel, ok := splitMultiplexedPin(c.pin)
+		if ok != c.wantOK {
+			t.Errorf("splitMultiplexedPin(%q) ok = %v, want %v", c.pin, ok, c.wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if name != c.wantName || channel != c.wantChannel {
+			t.Errorf("splitMultiplexedPin(%q) = (%q, %d), want (%q, %d)", c.pin, name, channel, c.wantName, c.wantChannel)
+		}
+	}
+}
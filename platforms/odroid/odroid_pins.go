@@ -1,36 +1,38 @@
 package odroid
 
-var DigitalPinMap = map[string]int{
-	"4": 173,
-	"5": 174,
-	"6": 171,
-	"7": 192,
-	"8": 172,
-	"9": 191,
-	"10": 189,
-	"11": 190,
-	"13": 21,
-	"14": 210,
-	"15": 18,
-	"16": 209,
-	"17": 22,
-	"18": 19,
-	"19": 30,
-	"20": 28,
-	"21": 29,
-	"22": 31,
-	"24": 25,
-	"25": 23,
-	"26": 24,
-	"27": 33,
-	"[4]": 188,
-	"[5]": 34,
-	"[6]": 187,
-}
+import "gobot.io/x/gobot/platforms/odroid/host"
+
+// Capability, PinDesc and the pin errors now live in gobot.io/x/gobot/pin
+// (re-exported by the host package) so every board's Descriptor can share
+// them; these aliases keep the odroid package's public API unchanged.
+type (
+	Capability         = host.Capability
+	PinDesc            = host.PinDesc
+	PinNotFoundError   = host.PinNotFoundError
+	PinCapabilityError = host.PinCapabilityError
+)
 
-var AnalogPinMap = map[string]string{
-	"3": "in_voltage0_raw",
-	"23": "in_voltage3_raw",
-	"AIN0": "in_voltage0_raw",
-	"AIN3": "in_voltage3_raw",
-}
\ No newline at end of file
+// Capability flags. A pin may advertise more than one.
+const (
+	CapDigital = host.CapDigital
+	CapPWM     = host.CapPWM
+	CapAnalog  = host.CapAnalog
+)
+
+// lookupPin resolves name against the Adaptor's board Descriptor and
+// verifies it supports every capability set in required.
+func (o *Adaptor) lookupPin(name string, required Capability) (*PinDesc, error) {
+	if p, ok := o.descriptor.DigitalPins[name]; ok {
+		if p.Caps&required != required {
+			return nil, &PinCapabilityError{Pin: name, Required: required, Have: p.Caps}
+		}
+		return &p, nil
+	}
+	if p, ok := o.descriptor.AnalogPins[name]; ok {
+		if p.Caps&required != required {
+			return nil, &PinCapabilityError{Pin: name, Required: required, Have: p.Caps}
+		}
+		return &p, nil
+	}
+	return nil, &PinNotFoundError{Pin: name}
+}
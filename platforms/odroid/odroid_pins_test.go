@@ -0,0 +1,30 @@
+package odroid
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/platforms/odroid/host"
+)
+
+func TestAdaptorLookupPin(t *testing.T) {
+	descriptor, ok := host.Get(host.XU4Name)
+	if !ok {
+		t.Fatal("XU4 descriptor not registered")
+	}
+	a := &Adaptor{descriptor: descriptor}
+
+	if _, err := a.lookupPin("nope", CapDigital); err == nil {
+		t.Fatal("expected a PinNotFoundError for an unknown pin")
+	}
+	if _, err := a.lookupPin("3", CapDigital); err == nil {
+		t.Fatal("expected a PinCapabilityError requesting CapDigital on an analog-only pin")
+	}
+
+	desc, err := a.lookupPin("19", CapDigital|CapPWM)
+	if err != nil {
+		t.Fatalf("lookupPin(19): %v", err)
+	}
+	if desc.Chip != "gpa2" || desc.Line != 6 {
+		t.Errorf("pin 19 = %+v, want chip gpa2 line 6", desc)
+	}
+}
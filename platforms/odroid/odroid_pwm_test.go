@@ -0,0 +1,36 @@
+package odroid
+
+import "testing"
+
+func TestDutyCycleForDuty(t *testing.T) {
+	cases := []struct {
+		duty   byte
+		period uint32
+		want   uint32
+	}{
+		{0, pwmDefaultPeriod, 0},
+		{255, pwmDefaultPeriod, pwmDefaultPeriod},
+		{128, 1000000, 501960},
+	}
+	for _, c := range cases {
+		if got := dutyCycleForDuty(c.duty, c.period); got != c.want {
+			t.Errorf("dutyCycleForDuty(%d, %d) = %d, want %d", c.duty, c.period, got, c.want)
+		}
+	}
+}
+
+func TestPulseForAngle(t *testing.T) {
+	cases := []struct {
+		angle byte
+		want  uint32
+	}{
+		{0, minServoPulse},
+		{180, maxServoPulse},
+		{90, (minServoPulse + maxServoPulse) / 2},
+	}
+	for _, c := range cases {
+		if got := pulseForAngle(c.angle); got != c.want {
+			t.Errorf("pulseForAngle(%d) = %d, want %d", c.angle, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package sysfs
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// gpio-cdev ioctl numbers and request flags, from <linux/gpio.h>. The sysfs
+// GPIO class this package otherwise talks to (/sys/class/gpio/export) has
+// been deprecated upstream in favor of this character-device ABI, which
+// supports atomic multi-line requests and delivers edge events on a pollable
+// file descriptor instead of requiring a poll() on a sysfs "value" file.
+const (
+	gpioGetLineHandleIoctl       = 0xc16cb403
+	gpioGetLineEventIoctl        = 0xc030b404
+	gpiohandleGetLineValuesIoctl = 0xc040b408
+	gpiohandleSetLineValuesIoctl = 0xc040b409
+
+	gpiohandleRequestInput     = 1 << 0
+	gpiohandleRequestOutput    = 1 << 1
+	gpiohandleRequestActiveLow = 1 << 2
+
+	gpioeventRequestRisingEdge  = 1 << 0
+	gpioeventRequestFallingEdge = 1 << 1
+	gpioeventRequestBothEdges   = gpioeventRequestRisingEdge | gpioeventRequestFallingEdge
+
+	gpioHandleMaxLines = 64
+)
+
+// Edge selects which line transition(s) WaitForEdge reports.
+type Edge int
+
+// Edge values accepted by WaitForEdge.
+const (
+	EdgeRising Edge = iota
+	EdgeFalling
+	EdgeBoth
+)
+
+// gpiohandleRequest mirrors struct gpiohandle_request.
+type gpiohandleRequest struct {
+	lineOffsets   [gpioHandleMaxLines]uint32
+	flags         uint32
+	defaultValues [gpioHandleMaxLines]uint8
+	consumerLabel [32]byte
+	lines         uint32
+	fd            int32
+}
+
+// gpiohandleData mirrors struct gpiohandle_data.
+type gpiohandleData struct {
+	values [gpioHandleMaxLines]uint8
+}
+
+// gpioeventRequest mirrors struct gpioevent_request.
+type gpioeventRequest struct {
+	lineOffset    uint32
+	handleFlags   uint32
+	eventFlags    uint32
+	consumerLabel [32]byte
+	fd            int32
+}
+
+// gpioeventData mirrors struct gpioevent_data.
+type gpioeventData struct {
+	timestamp uint64
+	id        uint32
+}
+
+// GPIOCDevPin is a DigitalPinner backed by a Linux gpiochip character device
+// (/dev/gpiochipN) instead of the deprecated /sys/class/gpio sysfs tree.
+type GPIOCDevPin struct {
+	chipPath  string
+	line      uint32
+	chipFd    int
+	lineFd    int
+	activeLow bool
+	dir       string
+}
+
+// NewGPIOCDevPin returns a GPIOCDevPin for the given line on the named
+// gpiochip device, e.g. NewGPIOCDevPin("/dev/gpiochip0", 173).
+func NewGPIOCDevPin(chipPath string, line uint32) *GPIOCDevPin {
+	return &GPIOCDevPin{
+		chipPath: chipPath,
+		line:     line,
+		chipFd:   -1,
+		lineFd:   -1,
+	}
+}
+
+// Export opens the gpiochip device so lines can be requested. It is a no-op
+// if the chip is already open.
+func (p *GPIOCDevPin) Export() (err error) {
+	if p.chipFd != -1 {
+		return nil
+	}
+	p.chipFd, err = unix.Open(p.chipPath, unix.O_RDWR|unix.O_CLOEXEC, 0)
+	return
+}
+
+// Unexport releases the line handle and closes the gpiochip device.
+func (p *GPIOCDevPin) Unexport() (err error) {
+	if p.lineFd != -1 {
+		if e := unix.Close(p.lineFd); e != nil {
+			err = e
+		}
+		p.lineFd = -1
+	}
+	if p.chipFd != -1 {
+		if e := unix.Close(p.chipFd); e != nil {
+			err = e
+		}
+		p.chipFd = -1
+	}
+	return
+}
+
+// Direction requests the line as an input ("in") or output ("out"),
+// releasing any previously held line handle first.
+func (p *GPIOCDevPin) Direction(dir string) error {
+	if p.dir == dir && p.lineFd != -1 {
+		return nil
+	}
+	if err := p.Export(); err != nil {
+		return err
+	}
+	if p.lineFd != -1 {
+		unix.Close(p.lineFd)
+		p.lineFd = -1
+	}
+
+	flags, err := handleRequestFlags(dir, p.activeLow)
+	if err != nil {
+		return err
+	}
+
+	req := gpiohandleRequest{flags: flags, lines: 1}
+	req.lineOffsets[0] = p.line
+	copy(req.consumerLabel[:], "gobot")
+
+	if err := ioctl(p.chipFd, gpioGetLineHandleIoctl, uintptr(unsafe.Pointer(&req))); err != nil {
+		return fmt.Errorf("requesting line %d on %s: %w", p.line, p.chipPath, err)
+	}
+	p.lineFd = int(req.fd)
+	p.dir = dir
+	return nil
+}
+
+// handleRequestFlags builds the gpiohandle_request flags for requesting dir
+// ("in" or "out") with the given active-low setting.
+func handleRequestFlags(dir string, activeLow bool) (uint32, error) {
+	var flags uint32
+	switch dir {
+	case IN:
+		flags = gpiohandleRequestInput
+	case OUT:
+		flags = gpiohandleRequestOutput
+	default:
+		return 0, fmt.Errorf("invalid direction %q", dir)
+	}
+	if activeLow {
+		flags |= gpiohandleRequestActiveLow
+	}
+	return flags, nil
+}
+
+// Read returns the current value of the line (0 or 1).
+func (p *GPIOCDevPin) Read() (int, error) {
+	var data gpiohandleData
+	if err := ioctl(p.lineFd, gpiohandleGetLineValuesIoctl, uintptr(unsafe.Pointer(&data))); err != nil {
+		return 0, err
+	}
+	return int(data.values[0]), nil
+}
+
+// Write sets the line to val (0 or 1).
+func (p *GPIOCDevPin) Write(val int) error {
+	var data gpiohandleData
+	data.values[0] = uint8(val)
+	return ioctl(p.lineFd, gpiohandleSetLineValuesIoctl, uintptr(unsafe.Pointer(&data)))
+}
+
+// ActiveLow configures the line's active-low flag; it takes effect on the
+// next call to Direction.
+func (p *GPIOCDevPin) ActiveLow(activeLow bool) error {
+	p.activeLow = activeLow
+	return nil
+}
+
+// WaitForEdge blocks until the line transitions as described by edge, or
+// timeout elapses, returning whether an edge was observed. It requests its
+// own line-event file descriptor and polls it with poll(2) so the handle used for
+// Read/Write is left untouched.
+func (p *GPIOCDevPin) WaitForEdge(edge Edge, timeout time.Duration) (bool, error) {
+	if err := p.Export(); err != nil {
+		return false, err
+	}
+
+	eventFlags, err := eventRequestFlags(edge)
+	if err != nil {
+		return false, err
+	}
+
+	handleFlags := uint32(gpiohandleRequestInput)
+	if p.activeLow {
+		handleFlags |= gpiohandleRequestActiveLow
+	}
+
+	req := gpioeventRequest{
+		lineOffset:  p.line,
+		handleFlags: handleFlags,
+		eventFlags:  eventFlags,
+	}
+	copy(req.consumerLabel[:], "gobot")
+
+	if err := ioctl(p.chipFd, gpioGetLineEventIoctl, uintptr(unsafe.Pointer(&req))); err != nil {
+		return false, fmt.Errorf("requesting line event on %s: %w", p.chipPath, err)
+	}
+	eventFd := int(req.fd)
+	defer unix.Close(eventFd)
+
+	fds := []unix.PollFd{{Fd: int32(eventFd), Events: unix.POLLIN}}
+	n, err := unix.Poll(fds, int(timeout.Milliseconds()))
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	var ev gpioeventData
+	buf := (*[unsafe.Sizeof(ev)]byte)(unsafe.Pointer(&ev))[:]
+	if _, err := unix.Read(eventFd, buf); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// eventRequestFlags builds the gpioevent_request event flags for edge.
+func eventRequestFlags(edge Edge) (uint32, error) {
+	switch edge {
+	case EdgeRising:
+		return gpioeventRequestRisingEdge, nil
+	case EdgeFalling:
+		return gpioeventRequestFallingEdge, nil
+	case EdgeBoth:
+		return gpioeventRequestBothEdges, nil
+	default:
+		return 0, fmt.Errorf("invalid edge %v", edge)
+	}
+}
+
+// ioctl issues a blocking ioctl(2) against fd, returning an error if the
+// kernel reports one.
+func ioctl(fd int, req uintptr, arg uintptr) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), req, arg)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package sysfs
+
+import "testing"
+
+func TestHandleRequestFlags(t *testing.T) {
+	cases := []struct {
+		name      string
+		dir       string
+		activeLow bool
+		want      uint32
+		wantErr   bool
+	}{
+		{"in", IN, false, gpiohandleRequestInput, false},
+		{"out", OUT, false, gpiohandleRequestOutput, false},
+		{"in active-low", IN, true, gpiohandleRequestInput | gpiohandleRequestActiveLow, false},
+		{"out active-low", OUT, true, gpiohandleRequestOutput | gpiohandleRequestActiveLow, false},
+		{"invalid", "sideways", false, 0, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := handleRequestFlags(c.dir, c.activeLow)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("handleRequestFlags(%q, %v): expected an error", c.dir, c.activeLow)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("handleRequestFlags(%q, %v): %v", c.dir, c.activeLow, err)
+			}
+			if got != c.want {
+				t.Errorf("handleRequestFlags(%q, %v) = %#x, want %#x", c.dir, c.activeLow, got, c.want)
+			}
+		})
+	}
+}
+
+func TestEventRequestFlags(t *testing.T) {
+	cases := []struct {
+		edge    Edge
+		want    uint32
+		wantErr bool
+	}{
+		{EdgeRising, gpioeventRequestRisingEdge, false},
+		{EdgeFalling, gpioeventRequestFallingEdge, false},
+		{EdgeBoth, gpioeventRequestRisingEdge | gpioeventRequestFallingEdge, false},
+		{Edge(99), 0, true},
+	}
+
+	for _, c := range cases {
+		got, err := eventRequestFlags(c.edge)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("eventRequestFlags(%v): expected an error", c.edge)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("eventRequestFlags(%v): %v", c.edge, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("eventRequestFlags(%v) = %#x, want %#x", c.edge, got, c.want)
+		}
+	}
+}